@@ -5,6 +5,7 @@ package parse
 
 import (
 	"fmt"
+	"strconv"
 
 	a "github.com/google/puffs/lang/ast"
 	t "github.com/google/puffs/lang/token"
@@ -60,6 +61,93 @@ func (p *parser) parseFile() (*a.Node, error) {
 
 func (p *parser) parseTopLevelDecl() (*a.Node, error) {
 	switch p.src[0].ID {
+	case t.IDPackageID:
+		p.src = p.src[1:]
+		if len(p.src) == 0 || !p.src[0].IsLiteral() {
+			return nil, fmt.Errorf("parse: expected a string literal for packageid at %s:%d", p.filename, p.line())
+		}
+		id := p.src[0].ID
+		p.src = p.src[1:]
+		if p.peekID() != t.IDSemicolon {
+			return nil, fmt.Errorf("parse: expected (implicit) ';' at %s:%d", p.filename, p.line())
+		}
+		p.src = p.src[1:]
+		return &a.Node{Kind: a.KPackageID, ID0: id}, nil
+
+	case t.IDUse:
+		p.src = p.src[1:]
+		if len(p.src) == 0 || !p.src[0].IsLiteral() {
+			return nil, fmt.Errorf("parse: expected a string literal for use at %s:%d", p.filename, p.line())
+		}
+		path := p.src[0].ID
+		p.src = p.src[1:]
+		if p.peekID() != t.IDSemicolon {
+			return nil, fmt.Errorf("parse: expected (implicit) ';' at %s:%d", p.filename, p.line())
+		}
+		p.src = p.src[1:]
+		return &a.Node{Kind: a.KUse, ID0: path}, nil
+
+	case t.IDStruct:
+		p.src = p.src[1:]
+		flags := a.Flags(0)
+		name, err := p.parseIdent()
+		if err != nil {
+			return nil, err
+		}
+		if p.peekID() == t.IDQuestion {
+			flags |= a.FlagsSuspendible
+			p.src = p.src[1:]
+		}
+		// A struct's fields have the same "name type" shape as a func's
+		// params, so parseParamList / parseParam (via a.KParam nodes) do
+		// double duty here.
+		fields, err := p.parseParamList()
+		if err != nil {
+			return nil, err
+		}
+		if p.peekID() != t.IDSemicolon {
+			return nil, fmt.Errorf("parse: expected (implicit) ';' at %s:%d", p.filename, p.line())
+		}
+		p.src = p.src[1:]
+		return &a.Node{Kind: a.KStruct, Flags: flags, ID0: name, List0: fields}, nil
+
+	case t.IDConst:
+		p.src = p.src[1:]
+		name, err := p.parseIdent()
+		if err != nil {
+			return nil, err
+		}
+		typ, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		if p.peekID() != t.IDEq {
+			return nil, fmt.Errorf("parse: expected '=' for const at %s:%d", p.filename, p.line())
+		}
+		p.src = p.src[1:]
+		val, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peekID() != t.IDSemicolon {
+			return nil, fmt.Errorf("parse: expected (implicit) ';' at %s:%d", p.filename, p.line())
+		}
+		p.src = p.src[1:]
+		return &a.Node{Kind: a.KConst, ID0: name, LHS: typ, RHS: val}, nil
+
+	case t.IDError, t.IDSuspension:
+		keyword := p.src[0].ID
+		p.src = p.src[1:]
+		name, err := p.parseIdent()
+		if err != nil {
+			return nil, err
+		}
+		if p.peekID() != t.IDSemicolon {
+			return nil, fmt.Errorf("parse: expected (implicit) ';' at %s:%d", p.filename, p.line())
+		}
+		p.src = p.src[1:]
+		return &a.Node{Kind: a.KStatus, ID0: keyword, ID1: name}, nil
+
 	case t.IDFunc:
 		flags := a.Flags(0)
 		p.src = p.src[1:]
@@ -79,7 +167,7 @@ func (p *parser) parseTopLevelDecl() (*a.Node, error) {
 		if err != nil {
 			return nil, err
 		}
-		block, err := p.parseBlock()
+		block, err := p.parseBlock(flags)
 		if err != nil {
 			return nil, err
 		}
@@ -169,19 +257,127 @@ func (p *parser) parseParam() (*a.Node, error) {
 	if err != nil {
 		return nil, err
 	}
-	id1, id2, err := p.parseQualifiedIdent()
+	typ, err := p.parseType()
 	if err != nil {
 		return nil, err
 	}
 	return &a.Node{
 		Kind: a.KParam,
 		ID0:  id0,
-		ID1:  id1,
-		ID2:  id2,
+		LHS:  typ,
 	}, nil
 }
 
-func (p *parser) parseBlock() ([]*a.Node, error) {
+// parseType parses a Wuffs type:
+//
+//	u8
+//	u32[..= 4095]
+//	pkg.Name
+//	[4] u8
+//	[] u8
+//	ptr u8
+//	nptr reader1[..= 4096]
+//
+// It is represented as an a.KType node. Flags records which of the array,
+// slice, ptr or nptr forms this is (the zero Flags means a plain, possibly
+// refined, base type). For a base type, ID0 and ID1 are the (possibly
+// qualified) type name and LHS / RHS are the low / high bounds of an
+// optional "[LOW ..= HIGH]" refinement. For the other forms, LHS is the
+// inner type, and for an array, MHS is the element count.
+func (p *parser) parseType() (*a.Node, error) {
+	switch p.peekID() {
+	case t.IDOpenBracket:
+		p.src = p.src[1:]
+		count := (*a.Node)(nil)
+		if p.peekID() != t.IDCloseBracket {
+			n, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			count = n
+		}
+		if p.peekID() != t.IDCloseBracket {
+			return nil, fmt.Errorf("parse: expected ']' for type at %s:%d", p.filename, p.line())
+		}
+		p.src = p.src[1:]
+
+		inner, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		if count == nil {
+			return &a.Node{Kind: a.KType, Flags: a.FlagsTypeSlice, LHS: inner}, nil
+		}
+		return &a.Node{Kind: a.KType, Flags: a.FlagsTypeArray, LHS: inner, MHS: count}, nil
+
+	case t.IDPtr, t.IDNptr:
+		flags := a.FlagsTypePtr
+		if p.src[0].ID == t.IDNptr {
+			flags = a.FlagsTypeNptr
+		}
+		p.src = p.src[1:]
+		inner, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		return &a.Node{Kind: a.KType, Flags: flags, LHS: inner}, nil
+	}
+
+	id0, id1, err := p.parseQualifiedIdent()
+	if err != nil {
+		return nil, err
+	}
+	n := &a.Node{Kind: a.KType, ID0: id0, ID1: id1}
+
+	if p.peekID() != t.IDOpenBracket {
+		return n, nil
+	}
+	p.src = p.src[1:]
+
+	low := (*a.Node)(nil)
+	if p.peekID() != t.IDDotDotEq {
+		l, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		low = l
+	}
+	if p.peekID() != t.IDDotDotEq {
+		return nil, fmt.Errorf("parse: expected '..=' for type refinement at %s:%d", p.filename, p.line())
+	}
+	p.src = p.src[1:]
+
+	high, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if p.peekID() != t.IDCloseBracket {
+		return nil, fmt.Errorf("parse: expected ']' for type refinement at %s:%d", p.filename, p.line())
+	}
+	p.src = p.src[1:]
+
+	if low != nil && low.Kind == a.KLiteral && high.Kind == a.KLiteral {
+		lv, lok := p.literalValue(low.ID0)
+		hv, hok := p.literalValue(high.ID0)
+		if lok && hok && lv > hv {
+			return nil, fmt.Errorf("parse: invalid type refinement [%d ..= %d] at %s:%d", lv, hv, p.filename, p.line())
+		}
+	}
+
+	n.LHS, n.RHS = low, high
+	return n, nil
+}
+
+// literalValue returns the int64 value of a numeric literal token.
+func (p *parser) literalValue(id t.ID) (int64, bool) {
+	v, err := strconv.ParseInt(p.m.ByKey(id.Key()), 0, 64)
+	return v, err == nil
+}
+
+// parseBlock parses a "{" ... "}" sequence of statements. flags is the
+// enclosing function's flags, threaded through so that nested statements
+// (the bodies of "if", "while" and "iterate") know whether "?=" is allowed.
+func (p *parser) parseBlock(flags a.Flags) ([]*a.Node, error) {
 	if p.peekID() != t.IDOpenCurly {
 		return nil, fmt.Errorf("parse: expected '{' for block at %s:%d", p.filename, p.line())
 	}
@@ -194,7 +390,7 @@ func (p *parser) parseBlock() ([]*a.Node, error) {
 			return block, nil
 		}
 
-		s, err := p.parseStatement()
+		s, err := p.parseStatement(flags)
 		if err != nil {
 			return nil, err
 		}
@@ -208,15 +404,48 @@ func (p *parser) parseBlock() ([]*a.Node, error) {
 	return nil, fmt.Errorf("parse: expected '}' for block at %s:%d", p.filename, p.line())
 }
 
-func (p *parser) parseStatement() (*a.Node, error) {
-	// TODO: parse statements other than x = y.
+// assignOps is the set of plain and compound assignment operators.
+var assignOps = map[t.ID]bool{
+	t.IDEq:        true,
+	t.IDPlusEq:    true,
+	t.IDMinusEq:   true,
+	t.IDStarEq:    true,
+	t.IDSlashEq:   true,
+	t.IDPercentEq: true,
+	t.IDAmpEq:     true,
+	t.IDPipeEq:    true,
+	t.IDHatEq:     true,
+	t.IDShiftLEq:  true,
+	t.IDShiftREq:  true,
+}
+
+func (p *parser) parseStatement(flags a.Flags) (*a.Node, error) {
+	switch p.peekID() {
+	case t.IDIf:
+		return p.parseIf(flags)
+	case t.IDWhile:
+		return p.parseWhile(flags)
+	case t.IDIterate:
+		return p.parseIterate(flags)
+	case t.IDVar:
+		return p.parseVar()
+	case t.IDReturn:
+		return p.parseReturn()
+	case t.IDBreak, t.IDContinue:
+		return p.parseJump()
+	}
 
 	lhs, err := p.parseExpr()
 	if err != nil {
 		return nil, err
 	}
 
-	if p.peekID() != t.IDEq {
+	op := p.peekID()
+	if op == t.IDQuestionEq {
+		if flags&a.FlagsSuspendible == 0 {
+			return nil, fmt.Errorf("parse: '?=' outside a suspendible function at %s:%d", p.filename, p.line())
+		}
+	} else if !assignOps[op] {
 		return nil, fmt.Errorf("parse: expected '=' for statement at %s:%d", p.filename, p.line())
 	}
 	p.src = p.src[1:]
@@ -228,19 +457,425 @@ func (p *parser) parseStatement() (*a.Node, error) {
 
 	return &a.Node{
 		Kind: a.KAssign,
+		ID0:  op,
 		LHS:  lhs,
 		RHS:  rhs,
 	}, nil
 }
 
+// parseIf parses "if COND { ... }", with optional "else if COND { ... }"
+// links and a trailing "else { ... }".
+func (p *parser) parseIf(flags a.Flags) (*a.Node, error) {
+	p.src = p.src[1:] // Consume "if".
+	cond, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	body, err := p.parseBlock(flags)
+	if err != nil {
+		return nil, err
+	}
+
+	elseBody := []*a.Node(nil)
+	if p.peekID() == t.IDElse {
+		p.src = p.src[1:]
+		if p.peekID() == t.IDIf {
+			elseIf, err := p.parseIf(flags)
+			if err != nil {
+				return nil, err
+			}
+			elseBody = []*a.Node{elseIf}
+		} else {
+			elseBody, err = p.parseBlock(flags)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &a.Node{Kind: a.KIf, LHS: cond, List0: body, List1: elseBody}, nil
+}
+
+// parseWhile parses "while COND { ... }" or "while.LABEL COND { ... }".
+func (p *parser) parseWhile(flags a.Flags) (*a.Node, error) {
+	p.src = p.src[1:] // Consume "while".
+	label, err := p.parseOptionalLabel()
+	if err != nil {
+		return nil, err
+	}
+	cond, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	body, err := p.parseBlock(flags)
+	if err != nil {
+		return nil, err
+	}
+	return &a.Node{Kind: a.KWhile, ID0: label, LHS: cond, List0: body}, nil
+}
+
+// parseIterate parses a Wuffs "iterate.LABEL:N (VARS) { ... }" block. VARS
+// is a comma-separated list of "NAME TYPE (= EXPR)?" entries (see
+// parseIterateVar) giving the state that is threaded through each unrolled
+// round of the loop.
+func (p *parser) parseIterate(flags a.Flags) (*a.Node, error) {
+	p.src = p.src[1:] // Consume "iterate".
+	label, err := p.parseOptionalLabel()
+	if err != nil {
+		return nil, err
+	}
+	if p.peekID() != t.IDColon {
+		return nil, fmt.Errorf("parse: expected ':' for iterate at %s:%d", p.filename, p.line())
+	}
+	p.src = p.src[1:]
+	if len(p.src) == 0 || !p.src[0].IsLiteral() {
+		return nil, fmt.Errorf("parse: expected an unroll count for iterate at %s:%d", p.filename, p.line())
+	}
+	n := &a.Node{Kind: a.KLiteral, ID0: p.src[0].ID}
+	p.src = p.src[1:]
+
+	vars, err := p.parseIterateVars()
+	if err != nil {
+		return nil, err
+	}
+	body, err := p.parseBlock(flags)
+	if err != nil {
+		return nil, err
+	}
+	return &a.Node{Kind: a.KIterate, ID0: label, LHS: n, List0: vars, List1: body}, nil
+}
+
+func (p *parser) parseIterateVars() ([]*a.Node, error) {
+	if p.peekID() != t.IDOpenParen {
+		return nil, fmt.Errorf("parse: expected '(' for iterate at %s:%d", p.filename, p.line())
+	}
+	p.src = p.src[1:]
+
+	vars := []*a.Node(nil)
+	for len(p.src) > 0 {
+		if p.src[0].ID == t.IDCloseParen {
+			p.src = p.src[1:]
+			return vars, nil
+		}
+
+		v, err := p.parseIterateVar()
+		if err != nil {
+			return nil, err
+		}
+		vars = append(vars, v)
+
+		switch p.peekID() {
+		case t.IDCloseParen:
+			p.src = p.src[1:]
+			return vars, nil
+		case t.IDComma:
+			p.src = p.src[1:]
+		default:
+			return nil, fmt.Errorf("parse: expected ')' for iterate at %s:%d", p.filename, p.line())
+		}
+	}
+	return nil, fmt.Errorf("parse: expected ')' for iterate at %s:%d", p.filename, p.line())
+}
+
+// parseIterateVar parses one "NAME TYPE (= EXPR)?" entry of an iterate
+// statement's round state. Unlike parseVar, there is no leading "var"
+// keyword here: the parens after "iterate.LABEL:N" already mark the start
+// of the list, the same way a func's parens mark the start of its params.
+func (p *parser) parseIterateVar() (*a.Node, error) {
+	name, err := p.parseIdent()
+	if err != nil {
+		return nil, err
+	}
+	typ, err := p.parseType()
+	if err != nil {
+		return nil, err
+	}
+
+	rhs := (*a.Node)(nil)
+	if p.peekID() == t.IDEq {
+		p.src = p.src[1:]
+		rhs, err = p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &a.Node{Kind: a.KVar, ID0: name, LHS: typ, RHS: rhs}, nil
+}
+
+// parseVar parses "var IDENT TYPE" or "var IDENT TYPE = EXPR".
+func (p *parser) parseVar() (*a.Node, error) {
+	p.src = p.src[1:] // Consume "var".
+	name, err := p.parseIdent()
+	if err != nil {
+		return nil, err
+	}
+	typ, err := p.parseType()
+	if err != nil {
+		return nil, err
+	}
+
+	rhs := (*a.Node)(nil)
+	if p.peekID() == t.IDEq {
+		p.src = p.src[1:]
+		rhs, err = p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &a.Node{Kind: a.KVar, ID0: name, LHS: typ, RHS: rhs}, nil
+}
+
+// parseReturn parses "return" or "return EXPR".
+func (p *parser) parseReturn() (*a.Node, error) {
+	p.src = p.src[1:] // Consume "return".
+	rhs := (*a.Node)(nil)
+	if len(p.src) > 0 && p.peekID() != t.IDSemicolon {
+		r, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		rhs = r
+	}
+	return &a.Node{Kind: a.KReturn, RHS: rhs}, nil
+}
+
+// parseJump parses "break", "continue" and their labelled forms,
+// "break.LABEL" and "continue.LABEL".
+func (p *parser) parseJump() (*a.Node, error) {
+	keyword := p.src[0].ID
+	p.src = p.src[1:]
+	label, err := p.parseOptionalLabel()
+	if err != nil {
+		return nil, err
+	}
+	return &a.Node{Kind: a.KJump, ID0: keyword, ID1: label}, nil
+}
+
+// parseOptionalLabel parses the ".LABEL" suffix used by "while", "iterate",
+// "break" and "continue", having already consumed the keyword. It returns 0
+// if there is no label.
+func (p *parser) parseOptionalLabel() (t.ID, error) {
+	if p.peekID() != t.IDDot {
+		return 0, nil
+	}
+	p.src = p.src[1:]
+	return p.parseIdent()
+}
+
+// opPrecedence maps a binary operator token to its precedence: bigger
+// numbers bind tighter. "and" and "or" short-circuit and bind the loosest;
+// "*", "/", "%" bind the tightest.
+var opPrecedence = map[t.ID]int{
+	t.IDOr:          1,
+	t.IDAnd:         2,
+	t.IDEqEq:        3,
+	t.IDNotEq:       3,
+	t.IDLessThan:    3,
+	t.IDLessEq:      3,
+	t.IDGreaterThan: 3,
+	t.IDGreaterEq:   3,
+	t.IDPipe:        4,
+	t.IDHat:         4,
+	t.IDAmp:         5,
+	t.IDShiftL:      6,
+	t.IDShiftR:      6,
+	t.IDPlus:        7,
+	t.IDMinus:       7,
+	t.IDStar:        8,
+	t.IDSlash:       8,
+	t.IDPercent:     8,
+}
+
+// parseExpr parses an expression, such as "x.y[i].z(w) + 2 * v.w".
 func (p *parser) parseExpr() (*a.Node, error) {
-	// TODO: parse other expressions, such as x.y, unop x and x binop y.
+	return p.parseBinaryExpr(0)
+}
+
+// parseBinaryExpr implements precedence climbing. It parses a unary
+// expression and then, so long as the next token is a binary operator whose
+// precedence is at least minPrec, folds it and its right hand side into the
+// result, recursing with a higher minPrec so that tighter-binding operators
+// are parsed first.
+func (p *parser) parseBinaryExpr(minPrec int) (*a.Node, error) {
+	lhs, err := p.parseUnaryExpr()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.peekID()
+		prec, ok := opPrecedence[op]
+		if !ok || prec < minPrec {
+			return lhs, nil
+		}
+		p.src = p.src[1:]
+
+		rhs, err := p.parseBinaryExpr(prec + 1)
+		if err != nil {
+			return nil, err
+		}
+		lhs = &a.Node{
+			Kind: a.KBinop,
+			ID0:  op,
+			LHS:  lhs,
+			RHS:  rhs,
+		}
+	}
+}
+
+// parseUnaryExpr parses the prefix operators "!", "-" and "~", bottoming out
+// at parsePrimaryExpr.
+func (p *parser) parseUnaryExpr() (*a.Node, error) {
+	switch p.peekID() {
+	case t.IDNot, t.IDMinus, t.IDTilde:
+		op := p.src[0].ID
+		p.src = p.src[1:]
+		rhs, err := p.parseUnaryExpr()
+		if err != nil {
+			return nil, err
+		}
+		return &a.Node{
+			Kind: a.KUnop,
+			ID0:  op,
+			LHS:  rhs,
+		}, nil
+	}
+	return p.parsePrimaryExpr()
+}
+
+// parsePrimaryExpr parses an operand followed by any chain of "." field
+// access, "[" index or slice "]" and "(" call ")" suffixes.
+func (p *parser) parsePrimaryExpr() (*a.Node, error) {
+	lhs, err := p.parseOperandExpr()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch p.peekID() {
+		case t.IDDot:
+			p.src = p.src[1:]
+			id, err := p.parseIdent()
+			if err != nil {
+				return nil, err
+			}
+			lhs = &a.Node{Kind: a.KDot, ID0: id, LHS: lhs}
+
+		case t.IDOpenBracket:
+			p.src = p.src[1:]
+			lhs, err = p.parseIndexOrSliceSuffix(lhs)
+			if err != nil {
+				return nil, err
+			}
+
+		case t.IDOpenParen:
+			args, err := p.parseArgList()
+			if err != nil {
+				return nil, err
+			}
+			lhs = &a.Node{Kind: a.KCall, LHS: lhs, List0: args}
+
+		default:
+			return lhs, nil
+		}
+	}
+}
+
+// parseOperandExpr parses the innermost operand of an expression: a
+// parenthesized sub-expression, a literal or an identifier.
+func (p *parser) parseOperandExpr() (*a.Node, error) {
+	if len(p.src) == 0 {
+		return nil, fmt.Errorf("parse: expected expression at %s:%d", p.filename, p.line())
+	}
+
+	if p.src[0].ID == t.IDOpenParen {
+		p.src = p.src[1:]
+		n, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peekID() != t.IDCloseParen {
+			return nil, fmt.Errorf("parse: expected ')' at %s:%d", p.filename, p.line())
+		}
+		p.src = p.src[1:]
+		return n, nil
+	}
+
+	if p.src[0].IsLiteral() {
+		id := p.src[0].ID
+		p.src = p.src[1:]
+		return &a.Node{Kind: a.KLiteral, ID0: id}, nil
+	}
+
 	id, err := p.parseIdent()
 	if err != nil {
 		return nil, err
 	}
-	return &a.Node{
-		Kind: a.KIdent,
-		ID0:  id,
-	}, nil
+	return &a.Node{Kind: a.KIdent, ID0: id}, nil
+}
+
+// parseIndexOrSliceSuffix parses the "[i]" or "[i:j]" suffix of lhs, having
+// already consumed the opening "[".
+func (p *parser) parseIndexOrSliceSuffix(lhs *a.Node) (*a.Node, error) {
+	low := (*a.Node)(nil)
+	if p.peekID() != t.IDColon {
+		n, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		low = n
+	}
+
+	if p.peekID() == t.IDCloseBracket {
+		p.src = p.src[1:]
+		return &a.Node{Kind: a.KIndex, LHS: lhs, RHS: low}, nil
+	}
+
+	if p.peekID() != t.IDColon {
+		return nil, fmt.Errorf("parse: expected ':' or ']' for index or slice at %s:%d", p.filename, p.line())
+	}
+	p.src = p.src[1:]
+
+	high := (*a.Node)(nil)
+	if p.peekID() != t.IDCloseBracket {
+		n, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		high = n
+	}
+	if p.peekID() != t.IDCloseBracket {
+		return nil, fmt.Errorf("parse: expected ']' for index or slice at %s:%d", p.filename, p.line())
+	}
+	p.src = p.src[1:]
+	return &a.Node{Kind: a.KSlice, LHS: lhs, MHS: low, RHS: high}, nil
+}
+
+// parseArgList parses a parenthesized, comma-separated list of call
+// arguments, having not yet consumed the opening "(".
+func (p *parser) parseArgList() ([]*a.Node, error) {
+	p.src = p.src[1:]
+
+	args := []*a.Node(nil)
+	for len(p.src) > 0 {
+		if p.src[0].ID == t.IDCloseParen {
+			p.src = p.src[1:]
+			return args, nil
+		}
+
+		arg, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+
+		switch p.peekID() {
+		case t.IDCloseParen:
+			p.src = p.src[1:]
+			return args, nil
+		case t.IDComma:
+			p.src = p.src[1:]
+		default:
+			return nil, fmt.Errorf("parse: expected ')' for argument list at %s:%d", p.filename, p.line())
+		}
+	}
+	return nil, fmt.Errorf("parse: expected ')' for argument list at %s:%d", p.filename, p.line())
 }
@@ -0,0 +1,317 @@
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package parse
+
+import (
+	"testing"
+
+	a "github.com/google/puffs/lang/ast"
+	"github.com/google/puffs/lang/lex"
+)
+
+// newTestParser lexes src and returns a parser primed to parse it.
+func newTestParser(t *testing.T, src string) *parser {
+	t.Helper()
+	tokens, m, err := lex.Lex("test.wuffs", []byte(src))
+	if err != nil {
+		t.Fatalf("Lex(%q): %v", src, err)
+	}
+	return &parser{src: tokens, m: m, filename: "test.wuffs"}
+}
+
+// nodeString renders n as a compact, order-preserving s-expression, so that
+// tests can assert on a parsed tree's shape without hand-building a.Node
+// values to compare against.
+func (p *parser) nodeString(n *a.Node) string {
+	if n == nil {
+		return "_"
+	}
+	switch n.Kind {
+	case a.KIdent, a.KLiteral:
+		return p.m.ByKey(n.ID0.Key())
+	case a.KUnop:
+		return "(" + p.m.ByKey(n.ID0.Key()) + " " + p.nodeString(n.LHS) + ")"
+	case a.KBinop:
+		return "(" + p.m.ByKey(n.ID0.Key()) + " " + p.nodeString(n.LHS) + " " + p.nodeString(n.RHS) + ")"
+	case a.KDot:
+		return "(. " + p.nodeString(n.LHS) + " " + p.m.ByKey(n.ID0.Key()) + ")"
+	case a.KIndex:
+		return "([] " + p.nodeString(n.LHS) + " " + p.nodeString(n.RHS) + ")"
+	case a.KSlice:
+		return "([:] " + p.nodeString(n.LHS) + " " + p.nodeString(n.MHS) + " " + p.nodeString(n.RHS) + ")"
+	case a.KCall:
+		s := "(call " + p.nodeString(n.LHS)
+		for _, arg := range n.List0 {
+			s += " " + p.nodeString(arg)
+		}
+		return s + ")"
+	case a.KAssign:
+		return "(assign " + p.m.ByKey(n.ID0.Key()) + " " + p.nodeString(n.LHS) + " " + p.nodeString(n.RHS) + ")"
+	case a.KIf:
+		s := "(if " + p.nodeString(n.LHS) + " " + p.blockString(n.List0)
+		if len(n.List1) != 0 {
+			s += " " + p.blockString(n.List1)
+		}
+		return s + ")"
+	case a.KWhile:
+		label := ""
+		if n.ID0 != 0 {
+			label = "." + p.m.ByKey(n.ID0.Key())
+		}
+		return "(while" + label + " " + p.nodeString(n.LHS) + " " + p.blockString(n.List0) + ")"
+	case a.KIterate:
+		label := ""
+		if n.ID0 != 0 {
+			label = "." + p.m.ByKey(n.ID0.Key())
+		}
+		s := "(iterate" + label + ":" + p.nodeString(n.LHS) + " ("
+		for i, v := range n.List0 {
+			if i != 0 {
+				s += ", "
+			}
+			s += p.nodeString(v)
+		}
+		return s + ") " + p.blockString(n.List1) + ")"
+	case a.KReturn:
+		return "(return " + p.nodeString(n.RHS) + ")"
+	case a.KJump:
+		s := "(" + p.m.ByKey(n.ID0.Key())
+		if n.ID1 != 0 {
+			s += "." + p.m.ByKey(n.ID1.Key())
+		}
+		return s + ")"
+	case a.KVar:
+		s := "(var " + p.m.ByKey(n.ID0.Key())
+		if n.RHS != nil {
+			s += " = " + p.nodeString(n.RHS)
+		}
+		return s + ")"
+	case a.KType:
+		switch {
+		case n.Flags&a.FlagsTypeArray != 0:
+			return "([" + p.nodeString(n.MHS) + "] " + p.nodeString(n.LHS) + ")"
+		case n.Flags&a.FlagsTypeSlice != 0:
+			return "([] " + p.nodeString(n.LHS) + ")"
+		case n.Flags&a.FlagsTypePtr != 0:
+			return "(ptr " + p.nodeString(n.LHS) + ")"
+		case n.Flags&a.FlagsTypeNptr != 0:
+			return "(nptr " + p.nodeString(n.LHS) + ")"
+		}
+		s := p.m.ByKey(n.ID1.Key())
+		if n.ID0 != 0 {
+			s = p.m.ByKey(n.ID0.Key()) + "." + s
+		}
+		if n.LHS != nil || n.RHS != nil {
+			s += "[" + p.nodeString(n.LHS) + " ..= " + p.nodeString(n.RHS) + "]"
+		}
+		return s
+	case a.KParam:
+		return "(" + p.m.ByKey(n.ID0.Key()) + " " + p.nodeString(n.LHS) + ")"
+	case a.KPackageID:
+		return "(packageid " + p.m.ByKey(n.ID0.Key()) + ")"
+	case a.KUse:
+		return "(use " + p.m.ByKey(n.ID0.Key()) + ")"
+	case a.KStruct:
+		s := "(struct " + p.m.ByKey(n.ID0.Key())
+		for _, f := range n.List0 {
+			s += " " + p.nodeString(f)
+		}
+		return s + ")"
+	case a.KConst:
+		return "(const " + p.m.ByKey(n.ID0.Key()) + " " + p.nodeString(n.LHS) + " = " + p.nodeString(n.RHS) + ")"
+	case a.KStatus:
+		return "(" + p.m.ByKey(n.ID0.Key()) + " " + p.m.ByKey(n.ID1.Key()) + ")"
+	}
+	return "?"
+}
+
+// blockString renders a "{" ... "}" list of statements.
+func (p *parser) blockString(block []*a.Node) string {
+	s := "{"
+	for i, stmt := range block {
+		if i != 0 {
+			s += " "
+		}
+		s += p.nodeString(stmt)
+	}
+	return s + "}"
+}
+
+func TestParseExpr(t *testing.T) {
+	testCases := []struct{ src, want string }{
+		{"1 + 2 * 3", "(+ 1 (* 2 3))"},
+		{"1 - 2 - 3", "(- (- 1 2) 3)"},
+		{"a or b and c", "(or a (and b c))"},
+		{"(1 + 2) * 3", "(* (+ 1 2) 3)"},
+		{"!a", "(! a)"},
+		{"-x + 1", "(+ (- x) 1)"},
+		{"x.y[i].z(w)", "(call (. ([] (. x y) i) z) w)"},
+		{"x[i:j]", "([:] x i j)"},
+		{"x[:j]", "([:] x _ j)"},
+	}
+	for _, tc := range testCases {
+		p := newTestParser(t, tc.src)
+		n, err := p.parseExpr()
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tc.src, err)
+			continue
+		}
+		if got := p.nodeString(n); got != tc.want {
+			t.Errorf("%q: got %q, want %q", tc.src, got, tc.want)
+		}
+	}
+}
+
+func TestParseStatement(t *testing.T) {
+	testCases := []struct{ src, want string }{
+		{"x = 1", "(assign = x 1)"},
+		{"x += 1", "(assign += x 1)"},
+		{
+			"if a { x = 1 } else if b { x = 2 } else { x = 3 }",
+			"(if a {(assign = x 1)} {(if b {(assign = x 2)} {(assign = x 3)})})",
+		},
+		{"while.lp a { x = 1 }", "(while.lp a {(assign = x 1)})"},
+		{"return x", "(return x)"},
+		{"return", "(return _)"},
+		{"break", "(break)"},
+		{"continue.lp", "(continue.lp)"},
+		{"var x u8", "(var x)"},
+		{"var x u8 = 0", "(var x = 0)"},
+	}
+	for _, tc := range testCases {
+		p := newTestParser(t, tc.src)
+		n, err := p.parseStatement(0)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tc.src, err)
+			continue
+		}
+		if got := p.nodeString(n); got != tc.want {
+			t.Errorf("%q: got %q, want %q", tc.src, got, tc.want)
+		}
+	}
+}
+
+// TestParseStatementSuspendAssign checks that "?=" is only accepted inside a
+// suspendible function, as parseStatement is told via its flags argument.
+func TestParseStatementSuspendAssign(t *testing.T) {
+	const src = "x ?= f()"
+
+	if _, err := newTestParser(t, src).parseStatement(0); err == nil {
+		t.Errorf("%q: expected an error outside a suspendible function, got none", src)
+	}
+	if _, err := newTestParser(t, src).parseStatement(a.FlagsSuspendible); err != nil {
+		t.Errorf("%q: unexpected error inside a suspendible function: %v", src, err)
+	}
+}
+
+// TestParseIterate exercises parseIterateVar: the entries inside
+// "iterate.LABEL:N ( ... )" are "NAME TYPE (= EXPR)?" pairs with no leading
+// "var" keyword.
+func TestParseIterate(t *testing.T) {
+	const src = "iterate.lp:4 (x u8 = 0, y u8) { x = y }"
+	p := newTestParser(t, src)
+	n, err := p.parseStatement(0)
+	if err != nil {
+		t.Fatalf("%q: unexpected error: %v", src, err)
+	}
+	if n.Kind != a.KIterate {
+		t.Fatalf("%q: got Kind %v, want KIterate", src, n.Kind)
+	}
+	if len(n.List0) != 2 {
+		t.Fatalf("%q: got %d iterate vars, want 2", src, len(n.List0))
+	}
+	for i, name := range []string{"x", "y"} {
+		if got := p.m.ByKey(n.List0[i].ID0.Key()); got != name {
+			t.Errorf("%q: var %d: got name %q, want %q", src, i, got, name)
+		}
+	}
+}
+
+func TestParseType(t *testing.T) {
+	testCases := []struct{ src, want string }{
+		{"u8", "u8"},
+		{"u32[..= 4095]", "u32[_ ..= 4095]"},
+		{"u8[4 ..= 255]", "u8[4 ..= 255]"},
+		{"pkg.Name", "pkg.Name"},
+		{"[4] u8", "([4] u8)"},
+		{"[] u8", "([] u8)"},
+		{"ptr u8", "(ptr u8)"},
+		{"nptr reader1", "(nptr reader1)"},
+	}
+	for _, tc := range testCases {
+		p := newTestParser(t, tc.src)
+		n, err := p.parseType()
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tc.src, err)
+			continue
+		}
+		if got := p.nodeString(n); got != tc.want {
+			t.Errorf("%q: got %q, want %q", tc.src, got, tc.want)
+		}
+	}
+}
+
+// TestParseTypeBadRefinement checks the parse-time rejection of a
+// refinement whose literal low bound exceeds its literal high bound.
+func TestParseTypeBadRefinement(t *testing.T) {
+	const src = "u8[4 ..= 1]"
+	if _, err := newTestParser(t, src).parseType(); err == nil {
+		t.Errorf("%q: expected an error for a low > high refinement, got none", src)
+	}
+}
+
+func TestParseTopLevelDecl(t *testing.T) {
+	testCases := []struct{ src, want string }{
+		{"packageid \"zlib\"\n", "(packageid \"zlib\")"},
+		{"use \"std/crc32\"\n", "(use \"std/crc32\")"},
+		{"struct foo(x u8, y u8,)\n", "(struct foo (x u8) (y u8))"},
+		{"const bar u8 = 1\n", "(const bar u8 = 1)"},
+		{"error bad_checksum\n", "(error bad_checksum)"},
+		{"suspension short_read\n", "(suspension short_read)"},
+	}
+	for _, tc := range testCases {
+		p := newTestParser(t, tc.src)
+		n, err := p.parseTopLevelDecl()
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tc.src, err)
+			continue
+		}
+		if got := p.nodeString(n); got != tc.want {
+			t.Errorf("%q: got %q, want %q", tc.src, got, tc.want)
+		}
+	}
+}
+
+// TestParseFile is a from-scratch-ish smoke test: a minimal but complete
+// source file exercising packageid, use, struct and func together, which is
+// the combination the C code generator needs to see user-defined structs.
+func TestParseFile(t *testing.T) {
+	const src = `packageid "zlib"
+
+use "std/crc32"
+
+struct decoder?(
+	checksum u32,
+)
+
+func decoder.decode(dst writer1, src reader1)?() {
+	var x u8 = 0
+}
+`
+	p := newTestParser(t, src)
+	f, err := p.parseFile()
+	if err != nil {
+		t.Fatalf("parseFile: %v", err)
+	}
+
+	kinds := []a.Kind{a.KPackageID, a.KUse, a.KStruct, a.KFunc}
+	if len(f.List0) != len(kinds) {
+		t.Fatalf("got %d top level decls, want %d", len(f.List0), len(kinds))
+	}
+	for i, k := range kinds {
+		if f.List0[i].Kind != k {
+			t.Errorf("decl %d: got Kind %v, want %v", i, f.List0[i].Kind, k)
+		}
+	}
+}